@@ -4,8 +4,10 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	tdmTypes "github.com/ethereum/go-ethereum/consensus/tendermint/types"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	eth "github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/pchain/ethereum"
 	"github.com/pchain/version"
 	cfg "github.com/tendermint/go-config"
@@ -96,7 +98,7 @@ func StartChain(ctx *cli.Context, chain *Chain, startDone chan<- struct{}) error
 	return nil
 }
 
-func CreateChildChain(ctx *cli.Context, chainId string, validator tdmTypes.PrivValidator, keyJson []byte, validators []tdmTypes.GenesisValidator) error {
+func CreateChildChain(ctx *cli.Context, chainId string, validator tdmTypes.PrivValidator, keyJson []byte, chainConfig *params.ChainConfig, validators []tdmTypes.GenesisValidator, genesisParams *types.ChildChainGenesisParams) error {
 
 	// Get Tendermint config base on chain id
 	config := GetTendermintConfig(chainId, ctx)
@@ -115,7 +117,7 @@ func CreateChildChain(ctx *cli.Context, chainId string, validator tdmTypes.PrivV
 	validator.Save()
 
 	// Init the Ethereum Genesis
-	err := initEthGenesisFromExistValidator(chainId, config, validators)
+	err := initEthGenesisFromExistValidator(chainId, config, chainConfig, validators, genesisParams)
 	if err != nil {
 		return err
 	}
@@ -124,7 +126,7 @@ func CreateChildChain(ctx *cli.Context, chainId string, validator tdmTypes.PrivV
 	init_eth_blockchain(chainId, config.GetString("eth_genesis_file"), ctx)
 
 	// Init the Tendermint Genesis
-	init_em_files(config, chainId, config.GetString("eth_genesis_file"), validators)
+	init_em_files(config, chainId, config.GetString("eth_genesis_file"), validators, genesisParams)
 
 	return nil
 }
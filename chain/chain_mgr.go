@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/pchain/p2p"
 	"github.com/pchain/rpc"
 	"github.com/pkg/errors"
@@ -127,10 +128,12 @@ func (cm *ChainManager) LoadChains(childIds []string) error {
 }
 
 func (cm *ChainManager) InitCrossChainHelper() {
-	cm.cch.chainInfoDB = dbm.NewDB("chaininfo",
+	// Chain Info DB is written by the pending ops, wrap it with journal so it could be rolled back with the chain
+	cm.cch.chainInfoDB = core.NewJournalDB("", "chaininfo", dbm.NewDB("chaininfo",
 		cm.mainChain.Config.GetString("db_backend"),
-		cm.ctx.GlobalString(utils.DataDirFlag.Name))
+		cm.ctx.GlobalString(utils.DataDirFlag.Name)))
 	cm.cch.localTX3CacheDB, _ = ethdb.NewLDBDatabase(path.Join(cm.ctx.GlobalString(utils.DataDirFlag.Name), "tx3cache"), 0, 0)
+	core.InitTX3CacheMetrics(cm.cch.localTX3CacheDB)
 	if cm.ctx.GlobalBool(utils.RPCEnabledFlag.Name) {
 		host := "127.0.0.1" //cm.ctx.GlobalString(utils.RPCListenAddrFlag.Name)
 		port := cm.ctx.GlobalInt(utils.RPCPortFlag.Name)
@@ -301,7 +304,10 @@ func (cm *ChainManager) LoadChildChainInRT(chainId string) {
 	privValidatorFile := cm.mainChain.Config.GetString("priv_validator_file")
 	self := types.LoadPrivValidator(privValidatorFile)
 
-	err := CreateChildChain(cm.ctx, chainId, *self, keyJson, validators)
+	// child chain has the forks activated in the main chain at its start block
+	chainConfig := params.NewChildChainConfig(chainId, ethereum.BlockChain().Config(), cci.StartBlock)
+
+	err := CreateChildChain(cm.ctx, chainId, *self, keyJson, chainConfig, validators, cci.GenesisParams)
 	if err != nil {
 		log.Errorf("Create Child Chain %v failed! %v", chainId, err)
 		return
@@ -31,6 +31,9 @@ import (
 const (
 	OFFICIAL_MINIMUM_VALIDATORS = 1
 	OFFICIAL_MINIMUM_DEPOSIT    = "100000000000000000000000" // 100,000 * e18
+
+	OFFICIAL_MINIMUM_EPOCH_LENGTH = 100
+	OFFICIAL_MAXIMUM_REWARD_YEARS = 4 // reward is not halved in the first 4 years, so the pool could be released evenly
 )
 
 type CrossChainHelper struct {
@@ -54,7 +57,7 @@ func (cch *CrossChainHelper) GetClient() *ethclient.Client {
 }
 
 // CanCreateChildChain check the condition before send the create child chain into the tx pool
-func (cch *CrossChainHelper) CanCreateChildChain(from common.Address, chainId string, minValidators uint16, minDepositAmount *big.Int, startBlock, endBlock *big.Int) error {
+func (cch *CrossChainHelper) CanCreateChildChain(from common.Address, chainId string, minValidators uint16, minDepositAmount *big.Int, startBlock, endBlock *big.Int, genesisParams *types.ChildChainGenesisParams, stateDB *state.StateDB) error {
 
 	if chainId == MainChain {
 		return errors.New("you can't create PChain as a child chain, try use other name instead")
@@ -79,6 +82,10 @@ func (cch *CrossChainHelper) CanCreateChildChain(from common.Address, chainId st
 
 	// Check the minimum deposit amount
 	officialMinimumDeposit := math.MustParseBig256(OFFICIAL_MINIMUM_DEPOSIT)
+	if minDeposit, ok := epoch.GetGovernanceParam(stateDB, epoch.ParamMinChildChainDeposit); ok {
+		// Minimum deposit amount has been changed by governance proposal
+		officialMinimumDeposit = minDeposit
+	}
 	if minDepositAmount.Cmp(officialMinimumDeposit) == -1 {
 		return fmt.Errorf("Deposit amount is not meet the minimum official deposit amount (%v PI)", new(big.Int).Div(officialMinimumDeposit, big.NewInt(params.PI)))
 	}
@@ -95,11 +102,49 @@ func (cch *CrossChainHelper) CanCreateChildChain(from common.Address, chainId st
 		return errors.New("end block number has already passed")
 	}
 
+	// Check the Genesis Parameters
+	if genesisParams != nil {
+		if err := validateChildChainGenesisParams(genesisParams); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateChildChainGenesisParams(genesisParams *types.ChildChainGenesisParams) error {
+
+	// Zero value means the default one
+	if genesisParams.EpochLength != 0 && genesisParams.EpochLength < OFFICIAL_MINIMUM_EPOCH_LENGTH {
+		return fmt.Errorf("epoch length is not meet the minimum official epoch length (%v)", OFFICIAL_MINIMUM_EPOCH_LENGTH)
+	}
+	if genesisParams.GasLimit != 0 && genesisParams.GasLimit < params.MinGasLimit {
+		return fmt.Errorf("gas limit is not meet the minimum gas limit (%v)", params.MinGasLimit)
+	}
+
+	if genesisParams.RewardPool.Sign() == -1 {
+		return errors.New("reward pool can't be negative")
+	}
+	if genesisParams.RewardPool.Sign() == 1 && (genesisParams.RewardYears == 0 || genesisParams.RewardYears > OFFICIAL_MAXIMUM_REWARD_YEARS) {
+		return fmt.Errorf("reward years must be between 1 and %v", OFFICIAL_MAXIMUM_REWARD_YEARS)
+	}
+
+	allocated := make(map[common.Address]bool)
+	for _, alloc := range genesisParams.Alloc {
+		if allocated[alloc.Address] {
+			return fmt.Errorf("duplicated allocation for %x", alloc.Address)
+		}
+		if alloc.Amount.Sign() != 1 {
+			return fmt.Errorf("allocation amount for %x must be greater than 0", alloc.Address)
+		}
+		allocated[alloc.Address] = true
+	}
+
 	return nil
 }
 
 // CreateChildChain Save the Child Chain Data into the DB, the data will be used later during Block Commit Callback
-func (cch *CrossChainHelper) CreateChildChain(from common.Address, chainId string, minValidators uint16, minDepositAmount *big.Int, startBlock, endBlock *big.Int) error {
+func (cch *CrossChainHelper) CreateChildChain(from common.Address, chainId string, minValidators uint16, minDepositAmount *big.Int, startBlock, endBlock *big.Int, genesisParams *types.ChildChainGenesisParams) error {
 	log.Debug("CreateChildChain - start")
 
 	cci := &core.CoreChainInfo{
@@ -109,6 +154,7 @@ func (cch *CrossChainHelper) CreateChildChain(from common.Address, chainId strin
 		MinDepositAmount: minDepositAmount,
 		StartBlock:       startBlock,
 		EndBlock:         endBlock,
+		GenesisParams:    genesisParams,
 		JoinedValidators: make([]core.JoinedValidator, 0),
 	}
 	core.CreatePendingChildChainData(cch.chainInfoDB, cci)
@@ -264,6 +310,11 @@ func (cch *CrossChainHelper) GetTxFromMainChain(txHash common.Hash) *types.Trans
 	return tx
 }
 
+func (cch *CrossChainHelper) GetBlockFromMainChain(number uint64) *types.Block {
+	ethereum := MustGetEthereumFromNode(chainMgr.mainChain.EthNode)
+	return ethereum.BlockChain().GetBlockByNumber(number)
+}
+
 func (cch *CrossChainHelper) GetEpochFromMainChain() *epoch.Epoch {
 	ethereum := MustGetEthereumFromNode(chainMgr.mainChain.EthNode)
 	var ep *epoch.Epoch
@@ -468,15 +519,33 @@ func (cch *CrossChainHelper) ValidateTX3ProofData(proofData *types.TX3ProofData)
 		return err
 	}
 
-	// tx merkle proof verify
+	// tx and receipt merkle proof verify
+	receiptRequired, err := cch.tx3ReceiptRequired(ci)
+	if err != nil {
+		return err
+	}
+	if len(proofData.TxProofs) != len(proofData.TxIndexs) || receiptRequired && len(proofData.ReceiptProofs) != len(proofData.TxIndexs) {
+		return errors.New("tx3 proof data mismatch with tx indexs")
+	}
 	keybuf := new(bytes.Buffer)
 	for i, txIndex := range proofData.TxIndexs {
 		keybuf.Reset()
 		rlp.Encode(keybuf, uint(txIndex))
-		_, err, _ := trie.VerifyProof(header.TxHash, keybuf.Bytes(), proofData.TxProofs[i])
+		val, err, _ := trie.VerifyProof(header.TxHash, keybuf.Bytes(), proofData.TxProofs[i])
 		if err != nil {
 			return err
 		}
+
+		var tx3 types.Transaction
+		if err := rlp.DecodeBytes(val, &tx3); err != nil {
+			return err
+		}
+
+		if receiptRequired {
+			if err := verifyTX3Receipt(header, txIndex, proofData.ReceiptProofs[i], &tx3); err != nil {
+				return err
+			}
+		}
 	}
 
 	log.Debug("ValidateTX3ProofData - end")
@@ -491,8 +560,6 @@ func (cch *CrossChainHelper) ValidateTX4WithInMemTX3ProofData(tx4 *types.Transac
 		return core.ErrInvalidSender
 	}
 
-	var args pabi.WithdrawFromMainChainArgs
-
 	if !pabi.IsPChainContractAddr(tx4.To()) {
 		return errors.New("invalid TX4: wrong To()")
 	}
@@ -503,52 +570,333 @@ func (cch *CrossChainHelper) ValidateTX4WithInMemTX3ProofData(tx4 *types.Transac
 		return err
 	}
 
-	if function != pabi.WithdrawFromMainChain {
+	if function != pabi.WithdrawFromMainChain && function != pabi.TransferFromChildChain && function != pabi.RelayWithdrawFromMainChain {
 		return errors.New("invalid TX4: wrong function")
 	}
 
-	if err := pabi.ChainABI.UnpackMethodInputs(&args, pabi.WithdrawFromMainChain.String(), data[4:]); err != nil {
+	// TX3
+	tdmExtra, err := tdmTypes.ExtractTendermintExtra(tx3ProofData.Header)
+	if err != nil {
+		return err
+	}
+	ci := core.GetChainInfo(cch.chainInfoDB, tdmExtra.ChainID)
+	if ci == nil {
+		return fmt.Errorf("chain info %s not found", tdmExtra.ChainID)
+	}
+	receiptRequired, err := cch.tx3ReceiptRequired(ci)
+	if err != nil {
+		return err
+	}
+	tx3, err := verifyInMemTX3(tx3ProofData, receiptRequired)
+	if err != nil {
 		return err
 	}
 
-	// TX3
-	header := tx3ProofData.Header
+	signer2 := types.NewEIP155Signer(tx3.ChainId())
+	tx3From, err := types.Sender(signer2, tx3)
+	if err != nil {
+		return core.ErrInvalidSender
+	}
+
+	tx3Data := tx3.Data()
+	tx3Function, err := pabi.FunctionTypeFromId(tx3Data[:4])
 	if err != nil {
 		return err
 	}
+
+	// Does TX3 & TX4 Match
+	if function == pabi.WithdrawFromMainChain {
+		var args pabi.WithdrawFromMainChainArgs
+		if err := pabi.ChainABI.UnpackMethodInputs(&args, pabi.WithdrawFromMainChain.String(), data[4:]); err != nil {
+			return err
+		}
+
+		if tx3Function != pabi.WithdrawFromChildChain && tx3Function != pabi.WithdrawFromChildChainWithFee {
+			return errors.New("params are not consistent with tx in child chain")
+		}
+		tx3ChainId, _, err := core.DecodeWithdrawFromChildChain(tx3)
+		if err != nil {
+			return err
+		}
+
+		if from != tx3From || args.ChainId != tx3ChainId || args.Amount.Cmp(tx3.Value()) != 0 {
+			return errors.New("params are not consistent with tx in child chain")
+		}
+	} else if function == pabi.RelayWithdrawFromMainChain {
+		var args pabi.RelayWithdrawFromMainChainArgs
+		if err := pabi.ChainABI.UnpackMethodInputs(&args, pabi.RelayWithdrawFromMainChain.String(), data[4:]); err != nil {
+			return err
+		}
+
+		if tx3Function != pabi.WithdrawFromChildChain && tx3Function != pabi.WithdrawFromChildChainWithFee {
+			return errors.New("params are not consistent with tx in child chain")
+		}
+		tx3ChainId, relayFee, err := core.DecodeWithdrawFromChildChain(tx3)
+		if err != nil {
+			return err
+		}
+
+		// the withdrawal is relayed on behalf of the sender of tx3, so the sender of tx4 is not checked
+		if args.TxHash != tx3.Hash() || args.From != tx3From || args.ChainId != tx3ChainId || args.Amount.Cmp(tx3.Value()) != 0 ||
+			args.RelayFee.Cmp(relayFee) != 0 || params.CalcChainId(args.ChainId).Cmp(tx3.ChainId()) != 0 {
+			return errors.New("params are not consistent with tx in child chain")
+		}
+	} else {
+		var args pabi.TransferFromChildChainArgs
+		if err := pabi.ChainABI.UnpackMethodInputs(&args, pabi.TransferFromChildChain.String(), data[4:]); err != nil {
+			return err
+		}
+
+		if tx3Function != pabi.TransferToChildChain {
+			return errors.New("params are not consistent with tx in child chain")
+		}
+		var tx3Args pabi.TransferToChildChainArgs
+		if err := pabi.ChainABI.UnpackMethodInputs(&tx3Args, pabi.TransferToChildChain.String(), tx3Data[4:]); err != nil {
+			return err
+		}
+
+		// the transfer is sent by the proposer of the source chain, so the sender of tx4 is not checked
+		if args.TxHash != tx3.Hash() || args.From != tx3From || args.ToChainId != tx3Args.ChainId || args.To != tx3Args.To ||
+			args.Amount.Cmp(tx3.Value()) != 0 || params.CalcChainId(args.ChainId).Cmp(tx3.ChainId()) != 0 {
+			return errors.New("params are not consistent with tx in child chain")
+		}
+	}
+
+	return nil
+}
+
+// verifyInMemTX3 verify the tx3 with the proof of the in-memory tx3 proof data, returns the tx3
+func verifyInMemTX3(tx3ProofData *types.TX3ProofData, receiptRequired bool) (*types.Transaction, error) {
+	header := tx3ProofData.Header
+	if len(tx3ProofData.TxIndexs) == 0 || len(tx3ProofData.TxProofs) == 0 || receiptRequired && len(tx3ProofData.ReceiptProofs) == 0 {
+		return nil, errors.New("invalid TX3 proof data")
+	}
 	keybuf := new(bytes.Buffer)
 	rlp.Encode(keybuf, tx3ProofData.TxIndexs[0])
 	val, err, _ := trie.VerifyProof(header.TxHash, keybuf.Bytes(), tx3ProofData.TxProofs[0])
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var tx3 types.Transaction
 	err = rlp.DecodeBytes(val, &tx3)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	signer2 := types.NewEIP155Signer(tx3.ChainId())
-	tx3From, err := types.Sender(signer2, &tx3)
+	if receiptRequired {
+		if err := verifyTX3Receipt(header, tx3ProofData.TxIndexs[0], tx3ProofData.ReceiptProofs[0], &tx3); err != nil {
+			return nil, err
+		}
+	}
+
+	return &tx3, nil
+}
+
+// tx3ReceiptRequired returns whether the tx3 of the child chain must be proved with its receipt, decided by the
+// stored chain config of the main chain running in this node
+func (cch *CrossChainHelper) tx3ReceiptRequired(ci *core.ChainInfo) (bool, error) {
+	ethereum, err := getEthereumByChainId(MainChain)
 	if err != nil {
-		return core.ErrInvalidSender
+		return false, err
 	}
+	return tx3ReceiptRequired(ethereum.BlockChain().Config(), ci), nil
+}
 
-	var tx3Args pabi.WithdrawFromChildChainArgs
-	tx3Data := tx3.Data()
-	if err := pabi.ChainABI.UnpackMethodInputs(&tx3Args, pabi.WithdrawFromChildChain.String(), tx3Data[4:]); err != nil {
+// tx3ReceiptRequired returns whether the tx3 of the child chain must be proved with its receipt. The child chain
+// started after the TX3 Receipt fork of the main chain has the fork since its genesis (see params.NewChildChainConfig),
+// the child chain started before never records the tx3 log in the receipt
+func tx3ReceiptRequired(mainConfig *params.ChainConfig, ci *core.ChainInfo) bool {
+	return ci.StartBlock != nil && mainConfig.IsTX3Receipt(ci.StartBlock)
+}
+
+// VerifyMessageProofData verify the header of the source chain by its validators and the receipt proof of the message,
+// returns the message sent from the source chain
+func (cch *CrossChainHelper) VerifyMessageProofData(proofData *types.MessageProofData) (*types.CrossChainMessage, error) {
+	log.Debug("VerifyMessageProofData - start")
+
+	if proofData.Header == nil {
+		return nil, errors.New("invalid message proof data")
+	}
+
+	chainId, err := cch.verifyChainHeader(proofData.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := proofData.Message()
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.FromChainId != chainId {
+		return nil, fmt.Errorf("message from chain %s, but the block is from chain %s", msg.FromChainId, chainId)
+	}
+
+	log.Debug("VerifyMessageProofData - end")
+	return msg, nil
+}
+
+// verifyChainHeader verify the header of the main chain or the child chain by the committed seals of its validators,
+// returns the chain id of the header
+func (cch *CrossChainHelper) verifyChainHeader(header *types.Header) (string, error) {
+	// Don't waste time checking blocks from the future
+	if header.Time.Cmp(big.NewInt(time.Now().Unix())) > 0 {
+		return "", errors.New("block in the future")
+	}
+
+	tdmExtra, err := tdmTypes.ExtractTendermintExtra(header)
+	if err != nil {
+		return "", err
+	}
+
+	chainId := tdmExtra.ChainID
+	if chainId == "" {
+		return "", errors.New("invalid chain id")
+	}
+
+	if header.Nonce != (types.TendermintEmptyNonce) && !bytes.Equal(header.Nonce[:], types.TendermintNonce) {
+		return "", errors.New("invalid nonce")
+	}
+
+	if header.MixDigest != types.TendermintDigest {
+		return "", errors.New("invalid mix digest")
+	}
+
+	if header.UncleHash != types.TendermintNilUncleHash {
+		return "", errors.New("invalid uncle Hash")
+	}
+
+	if header.Difficulty == nil || header.Difficulty.Cmp(types.TendermintDefaultDifficulty) != 0 {
+		return "", errors.New("invalid difficulty")
+	}
+
+	var ep *epoch.Epoch
+	if chainId == MainChain {
+		if mainEp := cch.GetEpochFromMainChain(); mainEp != nil {
+			ep = mainEp.GetEpochByBlockNumber(tdmExtra.Height)
+		}
+	} else {
+		ci := core.GetChainInfo(cch.chainInfoDB, chainId)
+		if ci == nil {
+			return "", fmt.Errorf("chain info %s not found", chainId)
+		}
+		ep = ci.GetEpochByBlockNumber(tdmExtra.Height)
+	}
+	if ep == nil {
+		return "", fmt.Errorf("could not get epoch for block height %v", tdmExtra.Height)
+	}
+
+	valSet := ep.Validators
+	if !bytes.Equal(valSet.Hash(), tdmExtra.ValidatorsHash) {
+		return "", errors.New("inconsistent validator set")
+	}
+
+	seenCommit := tdmExtra.SeenCommit
+	if !bytes.Equal(tdmExtra.SeenCommitHash, seenCommit.Hash()) {
+		return "", errors.New("invalid committed seals")
+	}
+
+	if err = valSet.VerifyCommit(tdmExtra.ChainID, tdmExtra.Height, seenCommit); err != nil {
+		return "", err
+	}
+
+	return chainId, nil
+}
+
+// verifyTX3Receipt verify the receipt of tx3 against the receipt hash of the header,
+// tx3 must be executed successfully and withdraw the amount of the tx value
+func verifyTX3Receipt(header *types.Header, txIndex uint, receiptProof *types.BSKeyValueSet, tx3 *types.Transaction) error {
+	keybuf := new(bytes.Buffer)
+	rlp.Encode(keybuf, txIndex)
+	val, err, _ := trie.VerifyProof(header.ReceiptHash, keybuf.Bytes(), receiptProof)
+	if err != nil {
 		return err
 	}
 
-	// Does TX3 & TX4 Match
-	if from != tx3From || args.ChainId != tx3Args.ChainId || args.Amount.Cmp(tx3.Value()) != 0 {
-		return errors.New("params are not consistent with tx in child chain")
+	var receipt types.Receipt
+	if err := rlp.DecodeBytes(val, &receipt); err != nil {
+		return err
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("tx3 %x failed in child chain", tx3.Hash())
+	}
+
+	amount, ok := receipt.TX3Amount()
+	if !ok || amount.Cmp(tx3.Value()) != 0 {
+		return fmt.Errorf("tx3 %x withdrawn amount mismatch with the receipt", tx3.Hash())
+	}
+
+	return nil
+}
+
+// GetTransactionFromChain returns the tx in the chain or its tx pool, nil if not found.
+// The chain should be running in this node.
+func (cch *CrossChainHelper) GetTransactionFromChain(chainId string, txHash common.Hash) (*core.ChainTxLookup, error) {
+	ethereum, err := getEthereumByChainId(chainId)
+	if err != nil {
+		return nil, err
+	}
+	return lookupTransaction(ethereum, txHash), nil
+}
+
+// GetCompletionTxFromChain returns the tx completing the tx1 / tx3 in the chain or its tx pool, nil if not found.
+// The chain should be running in this node.
+func (cch *CrossChainHelper) GetCompletionTxFromChain(chainId string, txHash common.Hash) (*core.ChainTxLookup, error) {
+	ethereum, err := getEthereumByChainId(chainId)
+	if err != nil {
+		return nil, err
 	}
 
+	if hash := core.GetCrossChainLookupEntry(ethereum.ChainDb(), txHash); hash != (common.Hash{}) {
+		if lookup := lookupTransaction(ethereum, hash); lookup != nil {
+			return lookup, nil
+		}
+	}
+
+	pending, err := ethereum.TxPool().Pending()
+	if err != nil {
+		return nil, err
+	}
+	for _, txs := range pending {
+		for _, tx := range txs {
+			if completed, ok := core.CompletedTxHash(tx); ok && completed == txHash {
+				return &core.ChainTxLookup{Tx: tx}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func lookupTransaction(ethereum *eth.Ethereum, txHash common.Hash) *core.ChainTxLookup {
+	if tx, blockHash, blockNumber, _ := core.GetTransaction(ethereum.ChainDb(), txHash); tx != nil {
+		lookup := &core.ChainTxLookup{Tx: tx, BlockHash: blockHash, BlockNumber: blockNumber}
+		if receipt, _, _, _ := core.GetReceipt(ethereum.ChainDb(), txHash); receipt != nil {
+			lookup.Status = receipt.Status
+		}
+		return lookup
+	}
+	if tx := ethereum.TxPool().Get(txHash); tx != nil {
+		return &core.ChainTxLookup{Tx: tx}
+	}
 	return nil
 }
 
+func getEthereumByChainId(chainId string) (*eth.Ethereum, error) {
+	if chainId == MainChain {
+		return getEthereumFromNode(chainMgr.mainChain.EthNode)
+	}
+
+	chainMgr.createChildChainLock.Lock()
+	chain, ok := chainMgr.childChains[chainId]
+	chainMgr.createChildChainLock.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("chain %s is not running in this node", chainId)
+	}
+	return getEthereumFromNode(chain.EthNode)
+}
+
 // TX3LocalCache start
 func (cch *CrossChainHelper) GetTX3(chainId string, txHash common.Hash) *types.Transaction {
 	return core.GetTX3(cch.localTX3CacheDB, chainId, txHash)
@@ -570,6 +918,10 @@ func (cch *CrossChainHelper) GetAllTX3ProofData() []*types.TX3ProofData {
 	return core.GetAllTX3ProofData(cch.localTX3CacheDB)
 }
 
+func (cch *CrossChainHelper) ForEachTX3(cb func(chainId string, tx3 *types.Transaction) bool) {
+	core.ForEachTX3(cch.localTX3CacheDB, cb)
+}
+
 // TX3LocalCache end
 
 func MustGetEthereumFromNode(node *node.Node) *eth.Ethereum {
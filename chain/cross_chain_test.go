@@ -0,0 +1,42 @@
+package chain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestTX3ReceiptRequired(t *testing.T) {
+	mainConfig := &params.ChainConfig{PChainId: MainChain, TX3ReceiptBlock: big.NewInt(100)}
+
+	tests := []struct {
+		startBlock *big.Int
+		want       bool
+	}{
+		{nil, false},
+		{big.NewInt(50), false}, // the child chain created before the fork
+		{big.NewInt(100), true},
+		{big.NewInt(150), true},
+	}
+	for _, test := range tests {
+		ci := &core.ChainInfo{CoreChainInfo: core.CoreChainInfo{ChainId: "child0", StartBlock: test.startBlock}}
+		if required := tx3ReceiptRequired(mainConfig, ci); required != test.want {
+			t.Errorf("start block %v: receipt required %v, want %v", test.startBlock, required, test.want)
+		}
+		// the child chain records the tx3 log in the receipt only if the receipt is required
+		if test.startBlock == nil {
+			continue
+		}
+		childConfig := params.NewChildChainConfig("child0", mainConfig, test.startBlock)
+		if childConfig.IsTX3Receipt(big.NewInt(1)) != test.want {
+			t.Errorf("start block %v: child chain tx3 receipt fork %v, want %v", test.startBlock, childConfig.TX3ReceiptBlock, test.want)
+		}
+	}
+
+	// the main chain without the fork scheduled never requires the receipt
+	if tx3ReceiptRequired(&params.ChainConfig{PChainId: MainChain}, &core.ChainInfo{CoreChainInfo: core.CoreChainInfo{StartBlock: big.NewInt(150)}}) {
+		t.Errorf("receipt required without the fork")
+	}
+}
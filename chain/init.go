@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/core"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 
 	"encoding/json"
@@ -16,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/geth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/tendermint/epoch"
 	"github.com/ethereum/go-ethereum/consensus/tendermint/types"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/pkg/errors"
@@ -34,6 +36,9 @@ const (
 	LockReward = "11500000000000000000000000" // 11.5m
 
 	DefaultAccountPassword = "pchain"
+
+	DefaultEpochLength = 2592000
+	DefaultBlockTime   = 1000 // in ms
 )
 
 type BalaceAmount struct {
@@ -87,7 +92,7 @@ func init_cmd(ctx *cli.Context, config cfg.Config, chainId string, ethGenesisPat
 
 	init_eth_blockchain(chainId, ethGenesisPath, ctx)
 
-	init_em_files(config, chainId, ethGenesisPath, nil)
+	init_em_files(config, chainId, ethGenesisPath, nil, nil)
 
 	return nil
 }
@@ -174,7 +179,7 @@ func init_eth_blockchain(chainId string, ethGenesisPath string, ctx *cli.Context
 	log.Infof("successfully wrote genesis block and/or chain rule set: %x", block.Hash())
 }
 
-func init_em_files(config cfg.Config, chainId string, genesisPath string, validators []types.GenesisValidator) error {
+func init_em_files(config cfg.Config, chainId string, genesisPath string, validators []types.GenesisValidator, genesisParams *ethTypes.ChildChainGenesisParams) error {
 	gensisFile, err := os.Open(genesisPath)
 	defer gensisFile.Close()
 	if err != nil {
@@ -204,14 +209,14 @@ func init_em_files(config cfg.Config, chainId string, genesisPath string, valida
 	}
 
 	// Create the Genesis Doc
-	if err := createGenesisDoc(config, chainId, &coreGenesis, privValidator, validators); err != nil {
+	if err := createGenesisDoc(config, chainId, &coreGenesis, privValidator, validators, genesisParams); err != nil {
 		utils.Fatalf("failed to write genesis file: %v", err)
 		return err
 	}
 	return nil
 }
 
-func createGenesisDoc(config cfg.Config, chainId string, coreGenesis *core.Genesis, privValidator *types.PrivValidator, validators []types.GenesisValidator) error {
+func createGenesisDoc(config cfg.Config, chainId string, coreGenesis *core.Genesis, privValidator *types.PrivValidator, validators []types.GenesisValidator, genesisParams *ethTypes.ChildChainGenesisParams) error {
 	genFile := config.GetString("genesis_file")
 	if _, err := os.Stat(genFile); os.IsNotExist(err) {
 
@@ -234,6 +239,11 @@ func createGenesisDoc(config cfg.Config, chainId string, coreGenesis *core.Genes
 				TotalYear:          "0",
 			}
 		}
+		rewardScheme.MaxValidatorsSize = strconv.Itoa(epoch.DefaultMaxValidatorsSize)
+		rewardScheme.ValidatorsGrowthPercent = strconv.Itoa(epoch.DefaultValidatorsGrowthPercent)
+		rewardScheme.ProposeStartPercent = strconv.Itoa(epoch.NextEpochProposeStartPercent * 100)
+		rewardScheme.HashVoteEndPercent = strconv.Itoa(epoch.NextEpochHashVoteEndPercent * 100)
+		rewardScheme.RevealVoteEndPercent = strconv.Itoa(epoch.NextEpochRevealVoteEndPercent * 100)
 
 		var rewardPerBlock string
 		if chainId == MainChain {
@@ -242,6 +252,35 @@ func createGenesisDoc(config cfg.Config, chainId string, coreGenesis *core.Genes
 			rewardPerBlock = "0"
 		}
 
+		endBlock := "2592000"
+		var consensusTimeout types.ConsensusTimeoutDoc
+		if genesisParams != nil {
+			if genesisParams.EpochLength != 0 {
+				endBlock = strconv.FormatUint(genesisParams.EpochLength-1, 10)
+			}
+			if genesisParams.EpochLength != 0 || genesisParams.BlockTime != 0 {
+				rewardScheme.EpochNumberPerYear = strconv.FormatUint(epochNumberPerYear(genesisParams.EpochLength, genesisParams.BlockTime), 10)
+			}
+			if genesisParams.RewardPool.Sign() == 1 {
+				// Release the Reward Pool evenly in the reward years, the reward is not halved in the first 4 years
+				rewardFirstYear := new(big.Int).Div(genesisParams.RewardPool, big.NewInt(int64(genesisParams.RewardYears)))
+				rewardScheme.TotalReward = genesisParams.RewardPool.String()
+				rewardScheme.RewardFirstYear = rewardFirstYear.String()
+				rewardScheme.TotalYear = strconv.Itoa(int(genesisParams.RewardYears) - 1)
+
+				epochNumberPerYear, _ := strconv.ParseInt(rewardScheme.EpochNumberPerYear, 10, 64)
+				blocks, _ := strconv.ParseInt(endBlock, 10, 64)
+				rewardPerEpoch := new(big.Int).Div(rewardFirstYear, big.NewInt(epochNumberPerYear))
+				rewardPerBlock = new(big.Int).Div(rewardPerEpoch, big.NewInt(blocks+1)).String()
+			}
+			if genesisParams.TimeoutPropose != 0 {
+				consensusTimeout.Propose = strconv.FormatUint(genesisParams.TimeoutPropose, 10)
+			}
+			if genesisParams.BlockTime != 0 {
+				consensusTimeout.Commit = strconv.FormatUint(genesisParams.BlockTime, 10)
+			}
+		}
+
 		genDoc := types.GenesisDoc{
 			ChainID:      chainId,
 			Consensus:    types.CONSENSUS_POS,
@@ -251,10 +290,11 @@ func createGenesisDoc(config cfg.Config, chainId string, coreGenesis *core.Genes
 				Number:         "0",
 				RewardPerBlock: rewardPerBlock,
 				StartBlock:     "0",
-				EndBlock:       "2592000",
+				EndBlock:       endBlock,
 				BlockGenerated: "0",
 				Status:         "0",
 			},
+			ConsensusTimeout: consensusTimeout,
 		}
 
 		if privValidator != nil {
@@ -302,6 +342,22 @@ func createPriValidators(config cfg.Config, num int) []*types.PrivValidator {
 	return validators
 }
 
+// epochNumberPerYear estimate the epochs in one year with the epoch length and block time (in ms) of the child chain genesis,
+// zero value means the default one, which is 2592000 blocks per epoch and 1 block per second
+func epochNumberPerYear(epochLength, blockTime uint64) uint64 {
+	if epochLength == 0 {
+		epochLength = DefaultEpochLength
+	}
+	if blockTime == 0 {
+		blockTime = DefaultBlockTime
+	}
+	number := uint64(365*24*3600*1000) / (epochLength * blockTime)
+	if number == 0 {
+		number = 1
+	}
+	return number
+}
+
 func checkAccount(coreGenesis core.Genesis) (common.Address, *big.Int, error) {
 
 	coinbase := coreGenesis.Coinbase
@@ -332,10 +388,10 @@ func checkAccount(coreGenesis core.Genesis) (common.Address, *big.Int, error) {
 	return coinbase, amount, nil
 }
 
-func initEthGenesisFromExistValidator(childChainID string, childConfig cfg.Config, validators []types.GenesisValidator) error {
+func initEthGenesisFromExistValidator(childChainID string, childConfig cfg.Config, chainConfig *params.ChainConfig, validators []types.GenesisValidator, genesisParams *ethTypes.ChildChainGenesisParams) error {
 
 	var coreGenesis = core.Genesis{
-		Config:     params.NewChildChainConfig(childChainID),
+		Config:     chainConfig,
 		Nonce:      0xdeadbeefdeadbeef,
 		Timestamp:  0x0,
 		ParentHash: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000000"),
@@ -353,6 +409,28 @@ func initEthGenesisFromExistValidator(childChainID string, childConfig cfg.Confi
 		}
 	}
 
+	if genesisParams != nil {
+		if genesisParams.GasLimit != 0 {
+			coreGenesis.GasLimit = genesisParams.GasLimit
+		}
+		// Initial Allocations, funded by the owner in the main chain
+		for _, alloc := range genesisParams.Alloc {
+			account, ok := coreGenesis.Alloc[alloc.Address]
+			if !ok {
+				account = core.GenesisAccount{Balance: big.NewInt(0), Amount: big.NewInt(0)}
+			}
+			account.Balance = new(big.Int).Add(account.Balance, alloc.Amount)
+			coreGenesis.Alloc[alloc.Address] = account
+		}
+		// Contract Creation follows the choice of the owner from the first block
+		coreGenesis.Config.ContractPolicyBlock = big.NewInt(0)
+		if genesisParams.AllowContracts {
+			coreGenesis.Config.ContractPolicy = params.ContractPolicyAll
+		} else {
+			coreGenesis.Config.ContractPolicy = params.ContractPolicyDisallow
+		}
+	}
+
 	contents, err := json.Marshal(coreGenesis)
 	if err != nil {
 		utils.Fatalf("marshal coreGenesis failed")
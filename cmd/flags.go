@@ -70,6 +70,7 @@ var (
 	DebugFlags = []cli.Flag{
 		verbosityFlag, vmoduleFlag, backtraceAtFlag, debugFlag,
 		pprofFlag, pprofAddrFlag, pprofPortFlag,
+		prometheusFlag, prometheusAddrFlag, prometheusPortFlag,
 		memprofilerateFlag, blockprofilerateFlag, cpuprofileFlag, traceFlag,
 	}
 
@@ -108,6 +109,20 @@ var (
 		Usage: "pprof HTTP server listening interface",
 		Value: "127.0.0.1",
 	}
+	prometheusFlag = cli.BoolFlag{
+		Name:  "prometheus",
+		Usage: "Enable the Prometheus metrics HTTP server on /metrics",
+	}
+	prometheusPortFlag = cli.IntFlag{
+		Name:  "prometheusport",
+		Usage: "Prometheus metrics HTTP server listening port",
+		Value: 6061,
+	}
+	prometheusAddrFlag = cli.StringFlag{
+		Name:  "prometheusaddr",
+		Usage: "Prometheus metrics HTTP server listening interface",
+		Value: "127.0.0.1",
+	}
 	memprofilerateFlag = cli.IntFlag{
 		Name:  "memprofilerate",
 		Usage: "Turn on memory profiling with the given rate",
@@ -67,6 +67,16 @@ func main() {
 			Description: "Generate priv_validator.json for address",
 		},
 
+		{
+			Action: utils.MigrateFlags(VerifySupplyCmd),
+			Name:   "verify-supply",
+			Usage:  "verify-supply [chainId] [blockNumber]", //recompute the supply from the full state, the node must be stopped
+			Flags: []cli.Flag{
+				utils.DataDirFlag,
+			},
+			Description: "Recompute the supply from the full state and compare it with the tracked supply, start tracking the supply from the head block if not tracked yet",
+		},
+
 		// See consolecmd.go:
 		//gethmain.ConsoleCommand,
 		gethmain.AttachCommand,
@@ -84,7 +94,7 @@ func main() {
 
 		// Setup the Global Logger
 		commonLogDir := path.Join(logFolderFlag, "common")
-		log.NewLogger("", commonLogDir, ctx.GlobalInt(verbosityFlag.Name), ctx.GlobalBool(debugFlag.Name), ctx.GlobalString(vmoduleFlag.Name), ctx.GlobalString(backtraceAtFlag.Name))
+		log.NewLogger("", commonLogDir, ctx.GlobalInt(verbosityFlag.Name), ctx.GlobalBool(debugFlag.Name), ctx.GlobalString(vmoduleFlag.Name), ctx.GlobalString(backtraceAtFlag.Name), utils.MakeLogOptions(ctx))
 
 		// Tendermint Config
 		chain.Config = chain.GetTendermintConfig(chain.MainChain, ctx)
@@ -219,7 +229,21 @@ func newCliApp(version, usage string) *cli.App {
 
 		utils.PerfTestFlag,
 
+		utils.VoteAgentFlag,
+		utils.VoteAgentAmountFlag,
+
+		utils.RelayerFlag,
+		utils.RelayerPasswordFileFlag,
+		utils.RelayerMinBalanceFlag,
+
+		utils.CrossChainRelayerFlag,
+		utils.CrossChainRelayerPasswordFileFlag,
+		utils.CrossChainRelayerMinFeeFlag,
+
 		LogDirFlag,
+		utils.LogFormatFlag,
+		utils.LogMaxSizeFlag,
+		utils.LogMaxAgeFlag,
 		ChildChainFlag,
 
 		/*
@@ -197,6 +197,29 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.ExtraDataFlag,
 		},
 	},
+	{
+		Name: "VOTE AGENT",
+		Flags: []cli.Flag{
+			utils.VoteAgentFlag,
+			utils.VoteAgentAmountFlag,
+		},
+	},
+	{
+		Name: "RELAYER",
+		Flags: []cli.Flag{
+			utils.RelayerFlag,
+			utils.RelayerPasswordFileFlag,
+			utils.RelayerMinBalanceFlag,
+		},
+	},
+	{
+		Name: "CROSS CHAIN RELAYER",
+		Flags: []cli.Flag{
+			utils.CrossChainRelayerFlag,
+			utils.CrossChainRelayerPasswordFileFlag,
+			utils.CrossChainRelayerMinFeeFlag,
+		},
+	},
 	{
 		Name: "GAS PRICE ORACLE",
 		Flags: []cli.Flag{
@@ -213,6 +236,9 @@ var AppHelpFlagGroups = []flagGroup{
 	{
 		Name: "LOGGING AND DEBUGGING",
 		Flags: append([]cli.Flag{
+			utils.LogFormatFlag,
+			utils.LogMaxSizeFlag,
+			utils.LogMaxAgeFlag,
 			utils.MetricsEnabledFlag,
 			utils.FakePoWFlag,
 			utils.NoCompactionFlag,
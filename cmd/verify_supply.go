@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/cmd/geth"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/pchain/chain"
+	"gopkg.in/urfave/cli.v1"
+	"path/filepath"
+	"strconv"
+)
+
+// VerifySupplyCmd recompute the supply from the full state of the block and compare it with the tracked supply,
+// if the supply of the head block is not tracked yet (chain started before the supply tracker), the computed supply
+// is stored and the node tracks the supply of the following blocks from it
+// usage: pchain verify-supply [chainId] [blockNumber], the node must be stopped as the chain database is locked
+func VerifySupplyCmd(ctx *cli.Context) error {
+
+	chainId := chain.MainChain
+	if ctx.NArg() > 0 {
+		chainId = ctx.Args().Get(0)
+	}
+
+	chainDb, err := ethdb.NewLDBDatabase(filepath.Join(utils.MakeDataDir(ctx), chainId, gethmain.ClientIdentifier, "chaindata"), 0, 0)
+	if err != nil {
+		return fmt.Errorf("could not open database: %v", err)
+	}
+	defer chainDb.Close()
+
+	var block *types.Block
+	if ctx.NArg() > 1 {
+		number, err := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid block number: %v", err)
+		}
+		block = core.GetBlock(chainDb, core.GetCanonicalHash(chainDb, number), number)
+	} else {
+		hash := core.GetHeadBlockHash(chainDb)
+		block = core.GetBlock(chainDb, hash, core.GetBlockNumber(chainDb, hash))
+	}
+	if block == nil {
+		return errors.New("block not found")
+	}
+	fmt.Printf("Verify the supply of chain %s at block %v (%x)\n", chainId, block.Number(), block.Hash())
+
+	statedb, err := state.New(block.Root(), state.NewDatabase(chainDb))
+	if err != nil {
+		return fmt.Errorf("state of the block is not available, try an earlier block: %v", err)
+	}
+	computed, err := statedb.ComputeSupply()
+	if err != nil {
+		return fmt.Errorf("failed to iterate the state: %v", err)
+	}
+	printSupply("Computed", computed)
+
+	tracked := core.GetSupply(chainDb, block.Hash())
+	if tracked == nil {
+		if block.Hash() != core.GetHeadBlockHash(chainDb) {
+			return errors.New("supply of the block is not tracked, verify the head block to start tracking")
+		}
+		if err := core.WriteSupply(chainDb, block.Hash(), computed); err != nil {
+			return fmt.Errorf("failed to store the supply: %v", err)
+		}
+		fmt.Println("Supply of the head block stored, the supply of the following blocks will be tracked")
+		return nil
+	}
+	printSupply("Tracked", tracked)
+
+	if diff := computed.Diff(tracked); len(diff) > 0 {
+		for _, d := range diff {
+			fmt.Printf("Mismatch %s\n", d)
+		}
+		return errors.New("supply mismatch")
+	}
+	fmt.Println("Supply verified")
+	return nil
+}
+
+func printSupply(name string, supply *state.Supply) {
+	fmt.Printf("%s Supply:\n", name)
+	fmt.Printf("  Total:                    %v\n", supply.Total())
+	fmt.Printf("  Circulating:              %v\n", supply.Balance)
+	fmt.Printf("  Locked:                   %v\n", supply.Locked())
+	fmt.Printf("  DepositBalance:           %v\n", supply.DepositBalance)
+	fmt.Printf("  ChildChainDepositBalance: %v\n", supply.ChildChainDepositBalance)
+	fmt.Printf("  ChainBalance:             %v\n", supply.ChainBalance)
+	fmt.Printf("  DelegateBalance:          %v\n", supply.DelegateBalance)
+	fmt.Printf("  ProxiedBalance:           %v\n", supply.ProxiedBalance)
+	fmt.Printf("  DepositProxiedBalance:    %v\n", supply.DepositProxiedBalance)
+	fmt.Printf("  PendingRefundBalance:     %v\n", supply.PendingRefundBalance)
+}